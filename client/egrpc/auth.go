@@ -0,0 +1,31 @@
+package egrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TokenSource 提供鉴权 token，实现需要在 token 过期时自行刷新后再返回
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// perRPCTokenInterceptor 在每次请求前向 outgoing metadata 注入 `authorization: Bearer <token>`
+func perRPCTokenInterceptor(source TokenSource) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := source.Token(ctx)
+		if err != nil {
+			return err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// WithPerRPCToken 返回一个 DialOption，为每次 unary 调用附带 source 提供的 token，
+// source 报告过期时会自动刷新，无需重新建立连接
+func WithPerRPCToken(source TokenSource) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(perRPCTokenInterceptor(source))
+}
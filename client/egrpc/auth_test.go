@@ -0,0 +1,49 @@
+package egrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type stubTokenSource struct {
+	token string
+	err   error
+}
+
+func (s stubTokenSource) Token(ctx context.Context) (string, error) {
+	return s.token, s.err
+}
+
+func TestPerRPCTokenInterceptor_AppendsBearerHeader(t *testing.T) {
+	interceptor := perRPCTokenInterceptor(stubTokenSource{token: "abc123"})
+
+	var gotAuth string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		gotAuth = md.Get("authorization")[0]
+		return nil
+	}
+
+	err := interceptor(context.Background(), "/test/Method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer abc123", gotAuth)
+}
+
+func TestPerRPCTokenInterceptor_PropagatesTokenSourceError(t *testing.T) {
+	interceptor := perRPCTokenInterceptor(stubTokenSource{err: errors.New("token expired")})
+
+	called := false
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		called = true
+		return nil
+	}
+
+	err := interceptor(context.Background(), "/test/Method", nil, nil, nil, invoker)
+	assert.Error(t, err)
+	assert.False(t, called)
+}
@@ -0,0 +1,58 @@
+package egrpc
+
+import (
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+	"google.golang.org/grpc/codes"
+)
+
+// Config 是 egrpc client 的配置项
+type Config struct {
+	Addr string
+
+	EnableCPUUsage             bool
+	EnableTraceInterceptor     bool
+	EnableAccessInterceptor    bool
+	EnableAccessInterceptorReq bool
+	EnableAccessInterceptorRes bool
+	SlowLogThreshold           time.Duration
+
+	// EnableRetry 是否开启 unary 请求重试
+	EnableRetry bool
+	// RetryTimes 最大重试次数
+	RetryTimes int
+	// RetryInitialBackoff 首次重试前的等待时间
+	RetryInitialBackoff time.Duration
+	// RetryMaxBackoff 重试等待时间上限
+	RetryMaxBackoff time.Duration
+	// RetryJitterFraction 在退避时间基础上增加的随机抖动比例，取值 [0, 1)
+	RetryJitterFraction float64
+	// RetryCodes 命中以下错误码才会重试，默认 Unavailable、ResourceExhausted、Aborted
+	RetryCodes []codes.Code
+	// PerCallTimeout 单次重试尝试的超时时间，0 表示使用父 context 的超时
+	PerCallTimeout time.Duration
+
+	// AccessLogSampleRate 成功调用时记录请求/响应体日志的采样率，取值 [0, 1]，出错时总是记录
+	AccessLogSampleRate float64
+	// AccessLogMaxPayloadBytes 请求/响应体 JSON 序列化后的最大记录字节数，超出部分截断
+	AccessLogMaxPayloadBytes int
+	// RedactFields 需要脱敏的 proto 字段路径，点号分隔，如 user.password、card.pan
+	RedactFields []string
+	// PayloadFilter 按方法自定义是否记录请求/响应体，nil 表示都记录
+	PayloadFilter PayloadFilter
+
+	logger *elog.Component
+}
+
+// DefaultConfig 返回 egrpc client 的默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		RetryTimes:          0,
+		RetryInitialBackoff: 10 * time.Millisecond,
+		RetryMaxBackoff:     200 * time.Millisecond,
+		RetryJitterFraction: 0.1,
+		RetryCodes:          []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted},
+		logger:              elog.EgoLogger.With(elog.FieldComponent("egrpc")),
+	}
+}
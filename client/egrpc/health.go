@@ -0,0 +1,35 @@
+package egrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthCheck 对目标地址发起一次阻塞式健康探测，可直接用于 k8s readiness probe。
+// timeout <= 0 时使用 1 秒的默认超时
+func HealthCheck(addr string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return fmt.Errorf("egrpc: dial %s failed: %w", addr, err)
+	}
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("egrpc: health check %s failed: %w", addr, err)
+	}
+	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("egrpc: %s not serving, status=%s", addr, resp.GetStatus())
+	}
+	return nil
+}
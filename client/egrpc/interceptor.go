@@ -14,7 +14,6 @@ import (
 	"github.com/gotomicro/ego/core/etrace"
 	"github.com/gotomicro/ego/core/transport"
 	"github.com/gotomicro/ego/core/util/xdebug"
-	"github.com/gotomicro/ego/core/util/xstring"
 	"github.com/gotomicro/ego/internal/ecode"
 	"github.com/gotomicro/ego/internal/tools"
 
@@ -173,11 +172,12 @@ func loggerUnaryClientInterceptor(_logger *elog.Component, config *Config) grpc.
 			fields = append(fields, elog.FieldTid(etrace.ExtractTraceID(ctx)))
 		}
 
-		if config.EnableAccessInterceptorReq {
-			fields = append(fields, elog.Any("req", json.RawMessage(xstring.JSON(req))))
+		logPayload := shouldSamplePayload(config, err)
+		if config.EnableAccessInterceptorReq && logPayload && (config.PayloadFilter == nil || config.PayloadFilter.AllowReq(method, req)) {
+			fields = append(fields, elog.Any("req", json.RawMessage(marshalPayload(config, req))))
 		}
-		if config.EnableAccessInterceptorRes {
-			fields = append(fields, elog.Any("res", json.RawMessage(xstring.JSON(res))))
+		if config.EnableAccessInterceptorRes && logPayload && (config.PayloadFilter == nil || config.PayloadFilter.AllowRes(method, res)) {
+			fields = append(fields, elog.Any("res", json.RawMessage(marshalPayload(config, res))))
 		}
 
 		if config.SlowLogThreshold > time.Duration(0) && cost > config.SlowLogThreshold {
@@ -0,0 +1,66 @@
+package egrpc
+
+import (
+	"encoding/json"
+	"math/rand"
+	"unicode/utf8"
+
+	"github.com/gotomicro/ego/core/util/xstring"
+	"github.com/gotomicro/ego/internal/exredact"
+	"google.golang.org/protobuf/proto"
+)
+
+// PayloadFilter 允许按方法自定义是否记录请求/响应体日志，例如在 Login 等敏感接口上完全跳过
+type PayloadFilter interface {
+	// AllowReq 返回 false 时跳过该次调用的请求体日志
+	AllowReq(method string, req interface{}) bool
+	// AllowRes 返回 false 时跳过该次调用的响应体日志
+	AllowRes(method string, res interface{}) bool
+}
+
+// shouldSamplePayload 决定本次调用是否记录请求/响应体：出错时总是记录，成功时按 AccessLogSampleRate 采样
+func shouldSamplePayload(config *Config, err error) bool {
+	if err != nil {
+		return true
+	}
+	if config.AccessLogSampleRate <= 0 {
+		return false
+	}
+	if config.AccessLogSampleRate >= 1 {
+		return true
+	}
+	return rand.Float64() < config.AccessLogSampleRate
+}
+
+// marshalPayload 将 payload 序列化为 JSON，按 RedactFields 脱敏后再按 AccessLogMaxPayloadBytes 截断
+func marshalPayload(config *Config, payload interface{}) string {
+	if msg, ok := payload.(proto.Message); ok && len(config.RedactFields) > 0 {
+		msg = proto.Clone(msg)
+		exredact.Fields(msg.ProtoReflect(), config.RedactFields)
+		payload = msg
+	}
+
+	raw := xstring.JSON(payload)
+	if config.AccessLogMaxPayloadBytes > 0 && len(raw) > config.AccessLogMaxPayloadBytes {
+		return truncatePayload(raw, config.AccessLogMaxPayloadBytes)
+	}
+	return raw
+}
+
+// truncatePayload 将原始 JSON 重新包装为 {"truncated":true,"payload":"..."}，而不是直接在字节切片后拼接
+// JSON 语法片段（那样切出来的前缀几乎总是断在 key/数字/多字节字符中间，产出无法解析的 JSON）；
+// payload 取原始内容前 maxBytes 字节，按 UTF-8 字符边界回退，再交给 json.Marshal 转义
+func truncatePayload(raw string, maxBytes int) string {
+	prefix := raw[:maxBytes]
+	for len(prefix) > 0 && !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	out, err := json.Marshal(struct {
+		Truncated bool   `json:"truncated"`
+		Payload   string `json:"payload"`
+	}{Truncated: true, Payload: prefix})
+	if err != nil {
+		return raw
+	}
+	return string(out)
+}
@@ -0,0 +1,125 @@
+package egrpc
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/gotomicro/ego/core/emetric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type retryCallOptions struct {
+	retryTimes *int
+}
+
+type retryCallOption struct {
+	grpc.EmptyCallOption
+	apply func(*retryCallOptions)
+}
+
+// WithRetryTimes 为单次调用覆盖 Config.RetryTimes
+func WithRetryTimes(n int) grpc.CallOption {
+	return retryCallOption{apply: func(o *retryCallOptions) { o.retryTimes = &n }}
+}
+
+func parseRetryCallOptions(opts []grpc.CallOption) retryCallOptions {
+	var options retryCallOptions
+	for _, opt := range opts {
+		if o, ok := opt.(retryCallOption); ok {
+			o.apply(&options)
+		}
+	}
+	return options
+}
+
+// retryUnaryClientInterceptor 返回带指数退避的 unary 重试拦截器，仅对白名单错误码生效，
+// stream 请求不在本拦截器的处理范围内
+func retryUnaryClientInterceptor(logger *elog.Component, config *Config) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !config.EnableRetry {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		retryTimes := config.RetryTimes
+		if o := parseRetryCallOptions(opts); o.retryTimes != nil {
+			retryTimes = *o.retryTimes
+		}
+		if retryTimes <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		md, _ := metadata.FromOutgoingContext(ctx)
+
+		var err error
+		for attempt := 0; attempt <= retryTimes; attempt++ {
+			// 每次重试都拷贝一份 metadata，避免上一次尝试写入的 header 串到下一次
+			attemptCtx := metadata.NewOutgoingContext(ctx, md.Copy())
+			attemptCtx = metadata.AppendToOutgoingContext(attemptCtx, "x-retry-attempt", strconv.Itoa(attempt))
+
+			cancel := func() {}
+			if config.PerCallTimeout > 0 {
+				attemptCtx, cancel = context.WithTimeout(attemptCtx, config.PerCallTimeout)
+			}
+			err = invoker(attemptCtx, method, req, reply, cc, opts...)
+			cancel()
+
+			if err == nil || !isRetryableCode(config.RetryCodes, status.Code(err)) || attempt == retryTimes {
+				// ClientRetryCounter 的 label 顺序为 method、attempt、code，与其它 emetric 计数器保持一致
+				emetric.ClientRetryCounter.Inc(method, strconv.Itoa(attempt), status.Code(err).String())
+				return err
+			}
+
+			logger.Warn("retry",
+				elog.FieldMethod(method),
+				elog.FieldEvent("retry"),
+				elog.Any("attempt", attempt),
+				elog.FieldErr(err),
+			)
+
+			if deadline, ok := ctx.Deadline(); ok && time.Now().After(deadline) {
+				return err
+			}
+
+			backoff := nextBackoff(config, attempt)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		return err
+	}
+}
+
+func isRetryableCode(whitelist []codes.Code, code codes.Code) bool {
+	for _, c := range whitelist {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// nextBackoff 计算第 attempt 次重试前的等待时间：min(initial * 2^attempt, max) + rand[0, jitter*current)
+func nextBackoff(config *Config, attempt int) time.Duration {
+	current := config.RetryInitialBackoff * time.Duration(1<<uint(attempt))
+	if config.RetryMaxBackoff > 0 && current > config.RetryMaxBackoff {
+		current = config.RetryMaxBackoff
+	}
+	if config.RetryJitterFraction <= 0 {
+		return current
+	}
+	n := int64(float64(current) * config.RetryJitterFraction)
+	if n <= 0 {
+		return current
+	}
+	return current + time.Duration(rand.Int63n(n))
+}
@@ -0,0 +1,156 @@
+package egrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNextBackoff_CapsAtMax(t *testing.T) {
+	config := &Config{
+		RetryInitialBackoff: 10 * time.Millisecond,
+		RetryMaxBackoff:     50 * time.Millisecond,
+		RetryJitterFraction: 0,
+	}
+
+	assert.Equal(t, 10*time.Millisecond, nextBackoff(config, 0))
+	assert.Equal(t, 20*time.Millisecond, nextBackoff(config, 1))
+	assert.Equal(t, 40*time.Millisecond, nextBackoff(config, 2))
+	// 第 3 次本应是 80ms，但应被 RetryMaxBackoff 压到 50ms
+	assert.Equal(t, 50*time.Millisecond, nextBackoff(config, 3))
+}
+
+func TestNextBackoff_JitterWithinBounds(t *testing.T) {
+	config := &Config{
+		RetryInitialBackoff: 10 * time.Millisecond,
+		RetryMaxBackoff:     100 * time.Millisecond,
+		RetryJitterFraction: 0.5,
+	}
+
+	for i := 0; i < 50; i++ {
+		backoff := nextBackoff(config, 0)
+		assert.GreaterOrEqual(t, backoff, 10*time.Millisecond)
+		assert.LessOrEqual(t, backoff, 10*time.Millisecond+5*time.Millisecond+time.Nanosecond)
+	}
+}
+
+func TestIsRetryableCode(t *testing.T) {
+	whitelist := []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted}
+
+	assert.True(t, isRetryableCode(whitelist, codes.Unavailable))
+	assert.False(t, isRetryableCode(whitelist, codes.NotFound))
+}
+
+func TestParseRetryCallOptions(t *testing.T) {
+	opts := parseRetryCallOptions([]grpc.CallOption{})
+	assert.Nil(t, opts.retryTimes)
+
+	opts = parseRetryCallOptions([]grpc.CallOption{WithRetryTimes(3)})
+	assert.NotNil(t, opts.retryTimes)
+	assert.Equal(t, 3, *opts.retryTimes)
+}
+
+func TestRetryUnaryClientInterceptor_SucceedsAfterRetries(t *testing.T) {
+	config := &Config{
+		EnableRetry:         true,
+		RetryTimes:          3,
+		RetryInitialBackoff: time.Millisecond,
+		RetryMaxBackoff:     5 * time.Millisecond,
+		RetryCodes:          []codes.Code{codes.Unavailable},
+	}
+	interceptor := retryUnaryClientInterceptor(elog.EgoLogger, config)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls <= 2 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+
+	err := interceptor(context.Background(), "/test/Method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryUnaryClientInterceptor_StopsAtParentDeadline(t *testing.T) {
+	config := &Config{
+		EnableRetry:         true,
+		RetryTimes:          5,
+		RetryInitialBackoff: 50 * time.Millisecond,
+		RetryCodes:          []codes.Code{codes.Unavailable},
+	}
+	interceptor := retryUnaryClientInterceptor(elog.EgoLogger, config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	time.Sleep(15 * time.Millisecond) // 确保父 deadline 已过
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	err := interceptor(ctx, "/test/Method", nil, nil, nil, invoker)
+	assert.Error(t, err)
+	// 父 deadline 已过，失败后直接返回，不应再发起下一次尝试
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryUnaryClientInterceptor_ClonesMetadataAndInjectsAttemptHeader(t *testing.T) {
+	config := &Config{
+		EnableRetry:         true,
+		RetryTimes:          2,
+		RetryInitialBackoff: time.Millisecond,
+		RetryCodes:          []codes.Code{codes.Unavailable},
+	}
+	interceptor := retryUnaryClientInterceptor(elog.EgoLogger, config)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("app", "test"))
+
+	var attempts []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		attempts = append(attempts, md.Get("x-retry-attempt")[0])
+		assert.Equal(t, "test", md.Get("app")[0])
+		if len(attempts) <= 1 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+
+	err := interceptor(ctx, "/test/Method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1"}, attempts)
+}
+
+func TestRetryUnaryClientInterceptor_PerCallOverride(t *testing.T) {
+	config := &Config{
+		EnableRetry:         true,
+		RetryTimes:          0, // 默认不重试
+		RetryInitialBackoff: time.Millisecond,
+		RetryCodes:          []codes.Code{codes.Unavailable},
+	}
+	interceptor := retryUnaryClientInterceptor(elog.EgoLogger, config)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls <= 1 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+
+	err := interceptor(context.Background(), "/test/Method", nil, nil, nil, invoker, WithRetryTimes(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
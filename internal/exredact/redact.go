@@ -0,0 +1,101 @@
+// Package exredact walks a protoreflect.Message and overwrites the leaf values
+// matched by a set of dotted field paths, shared by both the egrpc server and
+// client payload-logging interceptors so the redaction walk only has one
+// implementation to get right.
+package exredact
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Fields 对命中 fields（点号分隔的字段路径，如 user.password）的叶子字段做脱敏，
+// 支持嵌套、repeated 和 map 字段
+func Fields(msg protoreflect.Message, fields []string) {
+	byHead := make(map[string][]string, len(fields))
+	for _, f := range fields {
+		head, rest, _ := strings.Cut(f, ".")
+		byHead[head] = append(byHead[head], rest)
+	}
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		rests, matched := byHead[string(fd.Name())]
+		if !matched {
+			return true
+		}
+		for _, rest := range rests {
+			if rest == "" {
+				redactLeaf(msg, fd, v)
+				continue
+			}
+			redactNested(fd, v, []string{rest})
+		}
+		return true
+	})
+}
+
+// redactNested 沿着点号路径递归到下一级消息；map/list 字段必须先按各自的元素类型展开，
+// 否则 fd.Kind() 对 map 字段恒为 MessageKind，会被误判成单一消息字段
+func redactNested(fd protoreflect.FieldDescriptor, v protoreflect.Value, rest []string) {
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Kind() != protoreflect.MessageKind {
+			return
+		}
+		v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+			Fields(mv.Message(), rest)
+			return true
+		})
+	case fd.IsList():
+		if fd.Kind() != protoreflect.MessageKind {
+			return
+		}
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			Fields(list.Get(i).Message(), rest)
+		}
+	case fd.Kind() == protoreflect.MessageKind:
+		Fields(v.Message(), rest)
+	}
+}
+
+// redactLeaf 将叶子字段覆盖为脱敏占位值。repeated/map 字段要求 Set 接收 List/Map 包装的
+// Value，因此逐个元素替换；标量字段目前仅处理字符串/字节类型（password、pan 等敏感信息的常见类型）
+func redactLeaf(msg protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	switch {
+	case fd.IsMap():
+		placeholder, ok := placeholderFor(fd.MapValue().Kind())
+		if !ok {
+			return
+		}
+		m := v.Map()
+		m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			m.Set(k, placeholder)
+			return true
+		})
+	case fd.IsList():
+		placeholder, ok := placeholderFor(fd.Kind())
+		if !ok {
+			return
+		}
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			list.Set(i, placeholder)
+		}
+	default:
+		if placeholder, ok := placeholderFor(fd.Kind()); ok {
+			msg.Set(fd, placeholder)
+		}
+	}
+}
+
+func placeholderFor(kind protoreflect.Kind) (protoreflect.Value, bool) {
+	switch kind {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString("***"), true
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte("***")), true
+	}
+	return protoreflect.Value{}, false
+}
@@ -0,0 +1,131 @@
+package exredact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// newTestMessage 构造一个不依赖 protoc 的动态 proto 消息，字段覆盖标量、repeated、
+// map<string,string> 以及嵌套 message，用于验证脱敏在这几种 cardinality 下都不会 panic
+func newTestMessage(t *testing.T) protoreflect.Message {
+	t.Helper()
+
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	strType := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	msgType := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+
+	entryName := "MetadataEntry"
+	entryTypeName := ".test.User.MetadataEntry"
+	cardTypeName := ".test.Card"
+
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto("test.proto"),
+		Package: proto("test"),
+		Syntax:  proto("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto("Card"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto("pan"), Number: num(1), Label: &label, Type: &strType},
+				},
+			},
+			{
+				Name: proto("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto("name"), Number: num(1), Label: &label, Type: &strType},
+					{Name: proto("emails"), Number: num(2), Label: &repeated, Type: &strType},
+					{Name: proto("metadata"), Number: num(3), Label: &repeated, Type: &msgType, TypeName: proto(entryTypeName)},
+					{Name: proto("card"), Number: num(4), Label: &label, Type: &msgType, TypeName: proto(cardTypeName)},
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name:    proto(entryName),
+						Options: &descriptorpb.MessageOptions{MapEntry: proto(true)},
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto("key"), Number: num(1), Label: &label, Type: &strType},
+							{Name: proto("value"), Number: num(2), Label: &label, Type: &strType},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	file, err := protodesc.NewFile(fd, nil)
+	assert.NoError(t, err)
+
+	userDesc := file.Messages().ByName("User")
+	assert.NotNil(t, userDesc)
+
+	msg := dynamicpb.NewMessage(userDesc)
+	msg.Set(userDesc.Fields().ByName("name"), protoreflect.ValueOfString("alice"))
+
+	emails := msg.NewField(userDesc.Fields().ByName("emails")).List()
+	emails.Append(protoreflect.ValueOfString("a@example.com"))
+	emails.Append(protoreflect.ValueOfString("b@example.com"))
+	msg.Set(userDesc.Fields().ByName("emails"), protoreflect.ValueOfList(emails))
+
+	metadata := msg.NewField(userDesc.Fields().ByName("metadata")).Map()
+	metadata.Set(protoreflect.ValueOfString("ssn").MapKey(), protoreflect.ValueOfString("123-45-6789"))
+	msg.Set(userDesc.Fields().ByName("metadata"), protoreflect.ValueOfMap(metadata))
+
+	cardDesc := file.Messages().ByName("Card")
+	card := dynamicpb.NewMessage(cardDesc)
+	card.Set(cardDesc.Fields().ByName("pan"), protoreflect.ValueOfString("4111111111111111"))
+	msg.Set(userDesc.Fields().ByName("card"), protoreflect.ValueOfMessage(card))
+
+	return msg
+}
+
+func proto[T any](v T) *T { return &v }
+func num(n int32) *int32  { return &n }
+
+func TestFields_RepeatedExactMatch(t *testing.T) {
+	msg := newTestMessage(t)
+	assert.NotPanics(t, func() {
+		Fields(msg, []string{"emails"})
+	})
+
+	fd := msg.Descriptor().Fields().ByName("emails")
+	list := msg.Get(fd).List()
+	for i := 0; i < list.Len(); i++ {
+		assert.Equal(t, "***", list.Get(i).String())
+	}
+}
+
+func TestFields_MapScalarValueDoesNotPanic(t *testing.T) {
+	msg := newTestMessage(t)
+	assert.NotPanics(t, func() {
+		Fields(msg, []string{"metadata.ssn"})
+	})
+}
+
+func TestFields_MapExactMatch(t *testing.T) {
+	msg := newTestMessage(t)
+	assert.NotPanics(t, func() {
+		Fields(msg, []string{"metadata"})
+	})
+
+	fd := msg.Descriptor().Fields().ByName("metadata")
+	m := msg.Get(fd).Map()
+	m.Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+		assert.Equal(t, "***", v.String())
+		return true
+	})
+}
+
+func TestFields_NestedMessageField(t *testing.T) {
+	msg := newTestMessage(t)
+	Fields(msg, []string{"card.pan"})
+
+	fd := msg.Descriptor().Fields().ByName("card")
+	card := msg.Get(fd).Message()
+	panFd := card.Descriptor().Fields().ByName("pan")
+	assert.Equal(t, "***", card.Get(panFd).String())
+}
@@ -0,0 +1,129 @@
+package egrpc
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/gotomicro/ego/core/transport"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthFunc 校验请求并返回附带鉴权信息的 context，鉴权失败时返回非 nil error
+type AuthFunc func(ctx context.Context) (context.Context, error)
+
+// ServiceAuthFuncOverride 允许 gRPC service 为某个方法覆盖默认的 AuthFunc，
+// 常用于单个方法免鉴权（如健康检查、登录接口）
+type ServiceAuthFuncOverride interface {
+	AuthFuncOverride(ctx context.Context, fullMethod string) (context.Context, error)
+}
+
+// UnaryServerAuthInterceptor 返回 unary 鉴权拦截器。Build 将其注册在访问日志拦截器之前（外层），
+// 鉴权通过后写入 context 的 claims 对访问日志拦截器可见；鉴权失败时直接返回错误，请求不会进入
+// 访问日志拦截器，该错误码由更外层的 prometheus、trace 拦截器记录
+func UnaryServerAuthInterceptor(authFunc AuthFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newCtx, err := authFuncFor(ctx, info.Server, info.FullMethod, authFunc)
+		if err != nil {
+			return nil, err
+		}
+		return handler(newCtx, req)
+	}
+}
+
+// StreamServerAuthInterceptor 返回 stream 鉴权拦截器，语义同 UnaryServerAuthInterceptor
+func StreamServerAuthInterceptor(authFunc AuthFunc) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		newCtx, err := authFuncFor(ss.Context(), srv, info.FullMethod, authFunc)
+		if err != nil {
+			return err
+		}
+		return handler(srv, contextedServerStream{ServerStream: ss, ctx: newCtx})
+	}
+}
+
+func authFuncFor(ctx context.Context, srv interface{}, fullMethod string, authFunc AuthFunc) (context.Context, error) {
+	if override, ok := srv.(ServiceAuthFuncOverride); ok {
+		return override.AuthFuncOverride(ctx, fullMethod)
+	}
+	return authFunc(ctx)
+}
+
+// ClaimsContextKey 是解析出的鉴权信息在 transport 中的 key，下游 handler 和访问日志
+// 均可通过 tools.ContextValue(ctx, ClaimsContextKey) 取到
+const ClaimsContextKey = "auth-claims"
+
+// BearerAuthFunc 从 authorization 元数据头解析 `Bearer <token>`，并通过 verify 校验 token，
+// 校验通过后将 verify 返回的 claims 挂到 context 上
+func BearerAuthFunc(verify func(ctx context.Context, token string) (string, error)) AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		token, err := bearerToken(ctx)
+		if err != nil {
+			return ctx, err
+		}
+		claims, err := verify(ctx, token)
+		if err != nil {
+			return ctx, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return transport.WithValue(ctx, ClaimsContextKey, claims), nil
+	}
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+	const prefix = "Bearer "
+	if !strings.HasPrefix(vals[0], prefix) {
+		return "", status.Error(codes.Unauthenticated, "invalid authorization header format")
+	}
+	return strings.TrimPrefix(vals[0], prefix), nil
+}
+
+// BasicAuthFunc 从 authorization 元数据头解析 `Basic <base64(user:pass)>`，并通过 verify 校验，
+// 校验通过后将 verify 返回的 claims 挂到 context 上
+func BasicAuthFunc(verify func(ctx context.Context, username, password string) (string, error)) AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		username, password, err := basicAuth(ctx)
+		if err != nil {
+			return ctx, err
+		}
+		claims, err := verify(ctx, username, password)
+		if err != nil {
+			return ctx, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return transport.WithValue(ctx, ClaimsContextKey, claims), nil
+	}
+}
+
+func basicAuth(ctx context.Context) (string, string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return "", "", status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+	const prefix = "Basic "
+	if !strings.HasPrefix(vals[0], prefix) {
+		return "", "", status.Error(codes.Unauthenticated, "invalid authorization header format")
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(vals[0], prefix))
+	if err != nil {
+		return "", "", status.Error(codes.Unauthenticated, "invalid base64 in authorization header")
+	}
+	username, password, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return "", "", status.Error(codes.Unauthenticated, "invalid basic auth payload")
+	}
+	return username, password, nil
+}
@@ -0,0 +1,63 @@
+package egrpc
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func incomingCtx(key, value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(key, value))
+}
+
+func TestBearerToken(t *testing.T) {
+	token, err := bearerToken(incomingCtx("authorization", "Bearer abc123"))
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", token)
+}
+
+func TestBearerToken_MissingHeader(t *testing.T) {
+	_, err := bearerToken(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestBearerToken_WrongScheme(t *testing.T) {
+	_, err := bearerToken(incomingCtx("authorization", "Basic abc123"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestBasicAuth(t *testing.T) {
+	creds := base64.StdEncoding.EncodeToString([]byte("alice:hunter2"))
+	username, password, err := basicAuth(incomingCtx("authorization", "Basic "+creds))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", username)
+	assert.Equal(t, "hunter2", password)
+}
+
+func TestBasicAuth_InvalidBase64(t *testing.T) {
+	_, _, err := basicAuth(incomingCtx("authorization", "Basic not-base64!"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestBearerAuthFunc_VerifyFailure(t *testing.T) {
+	authFunc := BearerAuthFunc(func(ctx context.Context, token string) (string, error) {
+		return "", assert.AnError
+	})
+	_, err := authFunc(incomingCtx("authorization", "Bearer abc123"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestBearerAuthFunc_Success(t *testing.T) {
+	authFunc := BearerAuthFunc(func(ctx context.Context, token string) (string, error) {
+		assert.Equal(t, "abc123", token)
+		return "user-1", nil
+	})
+	newCtx, err := authFunc(incomingCtx("authorization", "Bearer abc123"))
+	assert.NoError(t, err)
+	assert.NotNil(t, newCtx)
+}
@@ -0,0 +1,58 @@
+package egrpc
+
+import (
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// Config 是 egrpc server 的配置项
+type Config struct {
+	Host    string
+	Port    int
+	Network string
+
+	SlowLogThreshold           time.Duration
+	EnableTraceInterceptor     bool
+	EnableAccessInterceptor    bool
+	EnableAccessInterceptorReq bool
+	EnableAccessInterceptorRes bool
+
+	// EnableLoadShedding 是否开启自适应过载保护
+	EnableLoadShedding bool
+	// CPUThreshold CPU 使用率硬阈值，800 代表 80%，超过后直接拒绝请求
+	CPUThreshold int32
+	// CPUSoftThreshold CPU 使用率软阈值，超过后按线性概率拒绝请求，平滑过载保护的拒绝曲线
+	CPUSoftThreshold int32
+	// MaxConcurrentRequests 允许的最大并发请求数
+	MaxConcurrentRequests int32
+	// SheddingExemptMethods 额外免于过载保护的方法全名；grpc.health.v1.Health 的 Check/Watch
+	// 始终免于过载保护，不需要在这里重复列出
+	SheddingExemptMethods []string
+
+	// AccessLogSampleRate 成功调用时记录请求/响应体日志的采样率，取值 [0, 1]，出错时总是记录
+	AccessLogSampleRate float64
+	// AccessLogMaxPayloadBytes 请求/响应体 JSON 序列化后的最大记录字节数，超出部分截断
+	AccessLogMaxPayloadBytes int
+	// RedactFields 需要脱敏的 proto 字段路径，点号分隔，如 user.password、card.pan
+	RedactFields []string
+	// RedactHeaders 需要脱敏的 metadata header 名
+	RedactHeaders []string
+	// PayloadFilter 按方法自定义是否记录请求/响应体，nil 表示都记录
+	PayloadFilter PayloadFilter
+
+	// AuthFunc 非 nil 时，Build 会自动注册鉴权拦截器，且注册在访问日志拦截器之前
+	AuthFunc AuthFunc
+	// RecoveryHandler 为 nil 时 Build 使用 defaultRecoveryHandler
+	RecoveryHandler RecoveryHandler
+
+	logger *elog.Component
+}
+
+// DefaultConfig 返回 egrpc server 的默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Network: "tcp",
+		logger:  elog.EgoLogger.With(elog.FieldComponent("egrpc")),
+	}
+}
@@ -0,0 +1,36 @@
+package egrpc
+
+import (
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ServingStatus 服务健康状态，对齐 grpc_health_v1.HealthCheckResponse_ServingStatus
+type ServingStatus = grpc_health_v1.HealthCheckResponse_ServingStatus
+
+const (
+	// ServingStatusUnknown 未知状态
+	ServingStatusUnknown = grpc_health_v1.HealthCheckResponse_UNKNOWN
+	// ServingStatusServing 服务正常，可以接收流量
+	ServingStatusServing = grpc_health_v1.HealthCheckResponse_SERVING
+	// ServingStatusNotServing 服务不可用，负载均衡应摘除该实例
+	ServingStatusNotServing = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+)
+
+// HealthServer 实现 grpc.health.v1.Health，同时支持 Check 和 Watch
+type HealthServer struct {
+	*health.Server
+}
+
+// newHealthServer 创建 HealthServer，默认整体状态为 SERVING
+func newHealthServer() *HealthServer {
+	hs := &HealthServer{Server: health.NewServer()}
+	hs.SetServingStatus("", ServingStatusServing)
+	return hs
+}
+
+// SetServingStatus 设置指定 service 的健康状态，service 为空字符串代表整体状态
+// 常用于优雅下线时将状态置为 NOT_SERVING，待负载均衡摘除该实例后再关闭监听
+func (c *Component) SetServingStatus(service string, status ServingStatus) {
+	c.healthServer.SetServingStatus(service, status)
+}
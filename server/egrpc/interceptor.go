@@ -2,10 +2,8 @@ package egrpc
 
 import (
 	"context"
-	"fmt"
 	"net"
 	"net/http"
-	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -14,7 +12,6 @@ import (
 	"github.com/gotomicro/ego/core/emetric"
 	"github.com/gotomicro/ego/core/etrace"
 	"github.com/gotomicro/ego/core/transport"
-	"github.com/gotomicro/ego/core/util/xstring"
 	"github.com/gotomicro/ego/internal/ecode"
 	"github.com/gotomicro/ego/internal/tools"
 	"github.com/gotomicro/ego/internal/xcpu"
@@ -108,18 +105,6 @@ func defaultStreamServerInterceptor(logger *elog.Component, config *Config) grpc
 		var event = "normal"
 		defer func() {
 			cost := time.Since(beg)
-			if rec := recover(); rec != nil {
-				switch rec := rec.(type) {
-				case error:
-					err = rec
-				default:
-					err = fmt.Errorf("%v", rec)
-				}
-				stack := make([]byte, 4096)
-				stack = stack[:runtime.Stack(stack, true)]
-				fields = append(fields, elog.FieldStack(stack))
-				event = "recover"
-			}
 			spbStatus := status.Convert(err)
 			httpStatusCode := ecode.GrpcToHTTPStatusCode(spbStatus.Code())
 
@@ -171,23 +156,8 @@ func defaultUnaryServerInterceptor(logger *elog.Component, config *Config) grpc.
 			}
 		}
 
-		// 此处必须使用defer来recover handler内部可能出现的panic
 		defer func() {
 			cost := time.Since(beg)
-			if rec := recover(); rec != nil {
-				switch recType := rec.(type) {
-				case error:
-					err = recType
-				default:
-					err = fmt.Errorf("%v", rec)
-				}
-
-				stack := make([]byte, 4096)
-				stack = stack[:runtime.Stack(stack, true)]
-				fields = append(fields, elog.FieldStack(stack))
-				event = "recover"
-			}
-
 			isSlow := false
 			if config.SlowLogThreshold > time.Duration(0) && config.SlowLogThreshold < cost {
 				isSlow = true
@@ -219,22 +189,29 @@ func defaultUnaryServerInterceptor(logger *elog.Component, config *Config) grpc.
 				}
 			}
 
+			// 客户端重试拦截器（client/egrpc 的 retryUnaryClientInterceptor）会注入 x-retry-attempt，
+			// 记录下来便于排查因重试放大的调用量
+			if attempt := tools.GrpcHeaderValue(ctx, "x-retry-attempt"); attempt != "" {
+				fields = append(fields, elog.FieldCustomKeyValue("retry-attempt", attempt))
+			}
+
 			if config.EnableTraceInterceptor && opentracing.IsGlobalTracerRegistered() {
 				fields = append(fields, elog.FieldTid(etrace.ExtractTraceID(ctx)))
 			}
 
-			if config.EnableAccessInterceptorReq {
+			logPayload := shouldSamplePayload(config, err)
+			if config.EnableAccessInterceptorReq && logPayload && (config.PayloadFilter == nil || config.PayloadFilter.AllowReq(info.FullMethod, req)) {
 				var reqMap = map[string]interface{}{
-					"payload": xstring.JSON(req),
+					"payload": marshalPayload(config, req),
 				}
 				if md, ok := metadata.FromIncomingContext(ctx); ok {
-					reqMap["metadata"] = md
+					reqMap["metadata"] = redactHeaders(md, config.RedactHeaders)
 				}
 				fields = append(fields, elog.Any("req", reqMap))
 			}
-			if config.EnableAccessInterceptorRes {
+			if config.EnableAccessInterceptorRes && logPayload && (config.PayloadFilter == nil || config.PayloadFilter.AllowRes(info.FullMethod, res)) {
 				fields = append(fields, elog.Any("res", map[string]interface{}{
-					"payload": xstring.JSON(res),
+					"payload": marshalPayload(config, res),
 				}))
 			}
 
@@ -0,0 +1,36 @@
+package egrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncatePayload_ProducesValidJSON(t *testing.T) {
+	raw := `{"user":{"age":30,"name":"alice","emails":["a@x.com","b@x.com"]}}`
+
+	truncated := truncatePayload(raw, 20)
+
+	var decoded struct {
+		Truncated bool   `json:"truncated"`
+		Payload   string `json:"payload"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(truncated), &decoded))
+	assert.True(t, decoded.Truncated)
+	assert.Equal(t, raw[:20], decoded.Payload)
+}
+
+func TestTruncatePayload_FallsBackToRuneBoundary(t *testing.T) {
+	raw := `{"name":"你好世界"}`
+
+	// 切在多字节字符中间
+	truncated := truncatePayload(raw, len(`{"name":"你`)+1)
+
+	var decoded struct {
+		Truncated bool   `json:"truncated"`
+		Payload   string `json:"payload"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(truncated), &decoded))
+	assert.Equal(t, `{"name":"你`, decoded.Payload)
+}
@@ -0,0 +1,83 @@
+package egrpc
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/gotomicro/ego/core/etrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryHandler 将 panic 恢复到的原始值转换为返回给调用方的 error，
+// 由业务决定返回的 gRPC 状态码（如 codes.Internal 还是 codes.FailedPrecondition）、
+// 是否在 error message 中携带堆栈（开发环境 vs 生产环境），以及是否上报 Sentry/OTel
+type RecoveryHandler func(ctx context.Context, p interface{}) error
+
+// stackBufMaxSize 限制堆栈缓冲区的扩容上限，避免深层 goroutine dump 无限增长内存
+const stackBufMaxSize = 1 << 20 // 1MB
+
+// growStack 抓取所有 goroutine 的堆栈，初始 4KB，不够容纳时倍增扩容，不再像之前那样被硬编码的 4KB 截断
+func growStack() []byte {
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= stackBufMaxSize {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// defaultRecoveryHandler 保持拆分前的行为：记录错误日志和堆栈，返回 codes.Internal
+func defaultRecoveryHandler(logger *elog.Component) RecoveryHandler {
+	return func(ctx context.Context, p interface{}) error {
+		err, ok := p.(error)
+		if !ok {
+			err = fmt.Errorf("%v", p)
+		}
+		logger.Error("recover",
+			elog.FieldEvent("recover"),
+			elog.FieldErr(err),
+			elog.FieldStack(growStack()),
+			elog.FieldTid(etrace.ExtractTraceID(ctx)),
+		)
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// RecoveryUnaryServerInterceptor 捕获 handler 内的 panic 并交给 handlerFn 处理，handlerFn 为 nil
+// 时使用 defaultRecoveryHandler。必须注册在拦截器链的最内层，这样 tracing/metrics/logging
+// 拦截器才能观察到恢复后生成的 error
+func RecoveryUnaryServerInterceptor(logger *elog.Component, handlerFn RecoveryHandler) grpc.UnaryServerInterceptor {
+	if handlerFn == nil {
+		handlerFn = defaultRecoveryHandler(logger)
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = handlerFn(ctx, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamServerInterceptor 是 RecoveryUnaryServerInterceptor 的 stream 版本
+func RecoveryStreamServerInterceptor(logger *elog.Component, handlerFn RecoveryHandler) grpc.StreamServerInterceptor {
+	if handlerFn == nil {
+		handlerFn = defaultRecoveryHandler(logger)
+	}
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := ss.Context()
+		defer func() {
+			if p := recover(); p != nil {
+				err = handlerFn(ctx, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
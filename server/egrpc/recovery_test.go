@@ -0,0 +1,83 @@
+package egrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func panicHandler(interface{}) (interface{}, error) {
+	panic(errors.New("boom"))
+}
+
+func TestRecoveryUnaryServerInterceptor_DefaultHandler(t *testing.T) {
+	interceptor := RecoveryUnaryServerInterceptor(elog.EgoLogger, nil)
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test/Panic"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return panicHandler(req)
+		})
+
+	assert.Nil(t, resp)
+	assert.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestRecoveryUnaryServerInterceptor_CustomHandler(t *testing.T) {
+	called := false
+	handlerFn := func(ctx context.Context, p interface{}) error {
+		called = true
+		return status.Error(codes.FailedPrecondition, "recovered")
+	}
+	interceptor := RecoveryUnaryServerInterceptor(elog.EgoLogger, handlerFn)
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test/Panic"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return panicHandler(req)
+		})
+
+	assert.True(t, called)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestRecoveryUnaryServerInterceptor_NoPanicPassesThrough(t *testing.T) {
+	interceptor := RecoveryUnaryServerInterceptor(elog.EgoLogger, nil)
+
+	resp, err := interceptor(context.Background(), "req", &grpc.UnaryServerInfo{FullMethod: "/test/OK"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f fakeServerStream) Context() context.Context { return f.ctx }
+
+func TestRecoveryStreamServerInterceptor_RecoversPanic(t *testing.T) {
+	interceptor := RecoveryStreamServerInterceptor(elog.EgoLogger, nil)
+
+	err := interceptor(nil, fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/test/Panic"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			panic("stream boom")
+		})
+
+	assert.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGrowStack(t *testing.T) {
+	stack := growStack()
+	assert.NotEmpty(t, stack)
+}
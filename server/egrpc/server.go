@@ -0,0 +1,88 @@
+package egrpc
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/gotomicro/ego/core/elog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Component 是 egrpc server 组件，对 *grpc.Server 的封装
+type Component struct {
+	*grpc.Server
+	config       *Config
+	listener     net.Listener
+	healthServer *HealthServer
+}
+
+// Build 根据 Config 创建 Component：自动注册 grpc.health.v1.Health 服务，并按固定顺序组装
+// 内置拦截器（由外到内）：过载保护 -> prometheus -> trace -> 鉴权（可选）-> 访问日志 -> panic 恢复，
+// panic 恢复拦截器必须在最内层，这样外层拦截器才能观察到恢复后生成的 error
+func Build(config *Config, opts ...grpc.ServerOption) *Component {
+	logger := config.logger
+	if logger == nil {
+		logger = elog.EgoLogger.With(elog.FieldComponent("egrpc"))
+	}
+
+	// 过载保护的并发数和滚动窗口计数要在 unary/stream 两条链路间共享同一个 loadShedder，
+	// 否则 MaxConcurrentRequests 实际只是 unary、stream 各自的独立上限
+	shedder := newLoadShedder(config)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		loadSheddingUnaryServerInterceptor(shedder),
+		prometheusUnaryServerInterceptor,
+		traceUnaryServerInterceptor,
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		loadSheddingStreamServerInterceptor(shedder),
+		prometheusStreamServerInterceptor,
+		traceStreamServerInterceptor,
+	}
+	if config.AuthFunc != nil {
+		unaryInterceptors = append(unaryInterceptors, UnaryServerAuthInterceptor(config.AuthFunc))
+		streamInterceptors = append(streamInterceptors, StreamServerAuthInterceptor(config.AuthFunc))
+	}
+	unaryInterceptors = append(unaryInterceptors,
+		defaultUnaryServerInterceptor(logger, config),
+		RecoveryUnaryServerInterceptor(logger, config.RecoveryHandler),
+	)
+	streamInterceptors = append(streamInterceptors,
+		defaultStreamServerInterceptor(logger, config),
+		RecoveryStreamServerInterceptor(logger, config.RecoveryHandler),
+	)
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
+	healthServer := newHealthServer()
+	server := grpc.NewServer(opts...)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	return &Component{
+		Server:       server,
+		config:       config,
+		healthServer: healthServer,
+	}
+}
+
+// Serve 监听 Config.Network/Host:Port 并开始处理请求，阻塞直到 GracefulStop 或发生错误
+func (c *Component) Serve() error {
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	listener, err := net.Listen(c.config.Network, addr)
+	if err != nil {
+		return fmt.Errorf("egrpc: listen %s failed: %w", addr, err)
+	}
+	c.listener = listener
+	return c.Server.Serve(listener)
+}
+
+// GracefulStop 优雅停止服务：先将所有 service 的健康状态置为 NOT_SERVING，
+// 随后立即调用 grpc.Server.GracefulStop 关闭监听并等待进行中的请求结束；两步之间不等待负载均衡摘除该实例
+func (c *Component) GracefulStop() {
+	c.healthServer.Shutdown()
+	c.Server.GracefulStop()
+}
@@ -0,0 +1,38 @@
+package egrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestBuild_RegistersHealthServingByDefault(t *testing.T) {
+	component := Build(DefaultConfig())
+	assert.NotNil(t, component.Server)
+
+	resp, err := component.healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+}
+
+func TestComponent_SetServingStatus(t *testing.T) {
+	component := Build(DefaultConfig())
+
+	component.SetServingStatus("my.service", ServingStatusNotServing)
+
+	resp, err := component.healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "my.service"})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
+
+func TestComponent_GracefulStop_MarksNotServing(t *testing.T) {
+	component := Build(DefaultConfig())
+
+	component.GracefulStop()
+
+	resp, err := component.healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
@@ -0,0 +1,103 @@
+package egrpc
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/gotomicro/ego/core/emetric"
+	"github.com/gotomicro/ego/internal/xcpu"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loadShedder 维护当前并发数，供过载保护拦截器判断是否放行；被拒绝的请求通过
+// emetric.ServerSheddingCounter 按请求上报，放行的请求不计数，也不维护进程内的滚动窗口计数
+type loadShedder struct {
+	config   *Config
+	inflight int32
+	exempt   map[string]bool
+}
+
+// defaultSheddingExemptMethods 是始终免于过载保护的方法，不依赖 Config.SheddingExemptMethods 配置；
+// Build 会在每个 Server 上自动注册健康检查服务，负载均衡的探活请求不应被自己的过载保护拒绝
+var defaultSheddingExemptMethods = []string{
+	"/grpc.health.v1.Health/Check",
+	"/grpc.health.v1.Health/Watch",
+}
+
+func newLoadShedder(config *Config) *loadShedder {
+	exempt := make(map[string]bool, len(defaultSheddingExemptMethods)+len(config.SheddingExemptMethods))
+	for _, m := range defaultSheddingExemptMethods {
+		exempt[m] = true
+	}
+	for _, m := range config.SheddingExemptMethods {
+		exempt[m] = true
+	}
+	return &loadShedder{config: config, exempt: exempt}
+}
+
+// allow 判断当前请求是否应当被放行；返回 false 时 reason 为拒绝原因，用于打点
+func (s *loadShedder) allow(fullMethod string) (bool, string) {
+	if !s.config.EnableLoadShedding || s.exempt[fullMethod] {
+		return true, ""
+	}
+
+	if inflight := atomic.LoadInt32(&s.inflight); s.config.MaxConcurrentRequests > 0 && inflight > s.config.MaxConcurrentRequests {
+		return false, "inflight"
+	}
+
+	var stat xcpu.Stat
+	xcpu.ReadStat(&stat)
+	usage := int32(stat.Usage)
+
+	if s.config.CPUThreshold > 0 && usage >= s.config.CPUThreshold {
+		return false, "cpu"
+	}
+
+	if soft := s.config.CPUSoftThreshold; soft > 0 && s.config.CPUThreshold > soft && usage > soft {
+		if rand.Float64() < rejectProbability(usage, soft, s.config.CPUThreshold) {
+			return false, "cpu_probabilistic"
+		}
+	}
+
+	return true, ""
+}
+
+// rejectProbability 计算软硬阈值之间的线性拒绝概率，避免过载保护本身造成抖动；
+// 调用方需保证 hard > soft
+func rejectProbability(usage, soft, hard int32) float64 {
+	return float64(usage-soft) / float64(hard-soft)
+}
+
+// loadSheddingUnaryServerInterceptor 在 handler 之前做过载判断，过载时直接返回 ResourceExhausted，
+// 不占用 handler 的处理开销；grpc.health.v1.Health 始终免于过载保护，其它关键 RPC 可加入
+// Config.SheddingExemptMethods 免于被拒绝
+func loadSheddingUnaryServerInterceptor(shedder *loadShedder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		atomic.AddInt32(&shedder.inflight, 1)
+		defer atomic.AddInt32(&shedder.inflight, -1)
+
+		if ok, reason := shedder.allow(info.FullMethod); !ok {
+			// ServerSheddingCounter 的 label 顺序为 method、reason
+			emetric.ServerSheddingCounter.Inc(info.FullMethod, reason)
+			return nil, status.Error(codes.ResourceExhausted, "server overloaded")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// loadSheddingStreamServerInterceptor 是 loadSheddingUnaryServerInterceptor 的 stream 版本
+func loadSheddingStreamServerInterceptor(shedder *loadShedder) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		atomic.AddInt32(&shedder.inflight, 1)
+		defer atomic.AddInt32(&shedder.inflight, -1)
+
+		if ok, reason := shedder.allow(info.FullMethod); !ok {
+			emetric.ServerSheddingCounter.Inc(info.FullMethod, reason)
+			return status.Error(codes.ResourceExhausted, "server overloaded")
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,99 @@
+package egrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoadShedder_AllowsWhenDisabled(t *testing.T) {
+	shedder := newLoadShedder(&Config{
+		EnableLoadShedding: false,
+		CPUThreshold:       1,
+	})
+
+	ok, reason := shedder.allow("/test/Method")
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+}
+
+func TestLoadShedder_AllowsHealthCheckByDefault(t *testing.T) {
+	shedder := newLoadShedder(&Config{
+		EnableLoadShedding:    true,
+		MaxConcurrentRequests: 1,
+	})
+	shedder.inflight = 100
+
+	ok, reason := shedder.allow("/grpc.health.v1.Health/Check")
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	ok, reason = shedder.allow("/grpc.health.v1.Health/Watch")
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+}
+
+func TestLoadShedder_AllowsExemptMethod(t *testing.T) {
+	shedder := newLoadShedder(&Config{
+		EnableLoadShedding:    true,
+		MaxConcurrentRequests: 1,
+		SheddingExemptMethods: []string{"/test/Critical"},
+	})
+	shedder.inflight = 100
+
+	ok, reason := shedder.allow("/test/Critical")
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+}
+
+func TestLoadShedder_RejectsOverMaxConcurrentRequests(t *testing.T) {
+	shedder := newLoadShedder(&Config{
+		EnableLoadShedding:    true,
+		MaxConcurrentRequests: 1,
+	})
+	shedder.inflight = 2
+
+	ok, reason := shedder.allow("/test/Method")
+	assert.False(t, ok)
+	assert.Equal(t, "inflight", reason)
+}
+
+func TestLoadShedder_SharedAcrossUnaryAndStream(t *testing.T) {
+	// unary 和 stream 拦截器必须共用同一个 loadShedder 实例，MaxConcurrentRequests
+	// 才是整个 Server 的并发上限，而不是 unary、stream 各自独立的上限
+	shedder := newLoadShedder(&Config{
+		EnableLoadShedding:    true,
+		MaxConcurrentRequests: 1,
+	})
+	unary := loadSheddingUnaryServerInterceptor(shedder)
+	stream := loadSheddingStreamServerInterceptor(shedder)
+
+	block := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		_, _ = unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test/Unary"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				close(done)
+				<-block
+				return nil, nil
+			})
+	}()
+	<-done
+
+	err := stream(nil, fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/test/Stream"},
+		func(srv interface{}, ss grpc.ServerStream) error { return nil })
+
+	close(block)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRejectProbability(t *testing.T) {
+	// soft=500, hard=800：刚过软阈值拒绝概率应接近 0，逼近硬阈值应接近 1
+	assert.Equal(t, 0.0, rejectProbability(500, 500, 800))
+	assert.InDelta(t, 0.5, rejectProbability(650, 500, 800), 0.001)
+	assert.Equal(t, 1.0, rejectProbability(800, 500, 800))
+}